@@ -0,0 +1,143 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// DefaultMaxSteps 是ReAct循环在放弃前允许的最大工具调用轮数。
+const DefaultMaxSteps = 5
+
+// systemPromptTemplate 告诉LLM可用的工具以及 ReAct 的输出格式约定：
+// 每一步要么给出 Action/Action Input 调用工具，要么直接给出 Final Answer。
+const systemPromptTemplate = "你是一个可以使用工具的助手。可用工具：\n%s\n" +
+	"请严格按照以下格式一步一步推理：\n" +
+	"Thought: 你的思考过程\n" +
+	"Action: 工具名称（必须是上面列出的一个）\n" +
+	"Action Input: 传给工具的输入\n" +
+	"观察结果会以 Observation: 开头反馈给你，之后继续 Thought/Action，" +
+	"直到你能够回答问题为止，这时输出：\n" +
+	"Thought: 我已经有足够信息回答问题\n" +
+	"Final Answer: 最终答案"
+
+// Agent 是一个 ReAct 风格的工具调用控制器。
+type Agent struct {
+	llm      llms.Model
+	tools    map[string]Tool
+	order    []string
+	maxSteps int
+}
+
+// New 创建一个 Agent，maxSteps<=0 时使用 DefaultMaxSteps。
+func New(llm llms.Model, tools []Tool, maxSteps int) *Agent {
+	if maxSteps <= 0 {
+		maxSteps = DefaultMaxSteps
+	}
+	a := &Agent{llm: llm, tools: make(map[string]Tool, len(tools)), maxSteps: maxSteps}
+	for _, t := range tools {
+		a.tools[t.Name()] = t
+		a.order = append(a.order, t.Name())
+	}
+	return a
+}
+
+// Run 驱动 ReAct 循环：让LLM选择工具、执行工具、把观察结果喂回去，
+// 直到LLM给出 Final Answer 或达到步数上限。
+func (a *Agent) Run(ctx context.Context, question string) (string, error) {
+	systemPrompt := fmt.Sprintf(systemPromptTemplate, a.toolDescriptions())
+
+	var transcript strings.Builder
+	transcript.WriteString(fmt.Sprintf("问题: %s\n", question))
+
+	for step := 0; step < a.maxSteps; step++ {
+		messages := []llms.MessageContent{
+			{Role: llms.ChatMessageTypeSystem, Parts: []llms.ContentPart{llms.TextContent{Text: systemPrompt}}},
+			{Role: llms.ChatMessageTypeHuman, Parts: []llms.ContentPart{llms.TextContent{Text: transcript.String()}}},
+		}
+
+		resp, err := a.llm.GenerateContent(ctx, messages, llms.WithTemperature(0))
+		if err != nil {
+			return "", fmt.Errorf("调用模型失败: %v", err)
+		}
+		if len(resp.Choices) == 0 {
+			return "", fmt.Errorf("模型没有返回任何内容")
+		}
+		content := resp.Choices[0].Content
+		transcript.WriteString(content + "\n")
+
+		if answer, ok := parseFinalAnswer(content); ok {
+			return answer, nil
+		}
+
+		action, actionInput, ok := parseAction(content)
+		if !ok {
+			// 模型既没有给出Action也没有给出Final Answer，把原始输出当作答案返回
+			return strings.TrimSpace(content), nil
+		}
+
+		tool, ok := a.tools[action]
+		if !ok {
+			transcript.WriteString(fmt.Sprintf("Observation: 未知工具 %q\n", action))
+			continue
+		}
+
+		observation, err := tool.Run(ctx, actionInput)
+		if err != nil {
+			observation = fmt.Sprintf("工具执行失败: %v", err)
+		}
+		transcript.WriteString(fmt.Sprintf("Observation: %s\n", observation))
+	}
+
+	return "", fmt.Errorf("达到最大步数(%d)仍未得到最终答案", a.maxSteps)
+}
+
+// toolDescriptions 按注册顺序列出每个工具的名称和描述，拼进系统提示词。
+func (a *Agent) toolDescriptions() string {
+	var out strings.Builder
+	for _, name := range a.order {
+		out.WriteString(fmt.Sprintf("- %s: %s\n", name, a.tools[name].Description()))
+	}
+	return out.String()
+}
+
+// parseFinalAnswer 从模型输出中提取 "Final Answer:" 之后的内容。
+func parseFinalAnswer(content string) (string, bool) {
+	for _, line := range strings.Split(content, "\n") {
+		if rest, ok := cutPrefix(line, "Final Answer:"); ok {
+			return strings.TrimSpace(rest), true
+		}
+	}
+	return "", false
+}
+
+// parseAction 从模型输出中提取 "Action:" 和 "Action Input:" 两行。
+func parseAction(content string) (action, input string, ok bool) {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if rest, found := cutPrefix(line, "Action:"); found {
+			action = strings.TrimSpace(rest)
+			if i+1 < len(lines) {
+				if rest, found := cutPrefix(lines[i+1], "Action Input:"); found {
+					input = strings.TrimSpace(rest)
+				}
+			}
+			return action, input, action != ""
+		}
+	}
+	return "", "", false
+}
+
+// cutPrefix 是 strings.CutPrefix 的大小写不敏感且允许前导空白的版本。
+func cutPrefix(line, prefix string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if len(trimmed) < len(prefix) {
+		return "", false
+	}
+	if strings.EqualFold(trimmed[:len(prefix)], prefix) {
+		return trimmed[len(prefix):], true
+	}
+	return "", false
+}
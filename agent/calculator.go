@@ -0,0 +1,87 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+// CalculatorTool 计算一个只含 +-*/() 和数字的算术表达式，
+// 复用 Go 自带的表达式解析器来避免手写一套运算符优先级逻辑。
+type CalculatorTool struct{}
+
+// Name 实现 Tool。
+func (t *CalculatorTool) Name() string { return "calculator" }
+
+// Description 实现 Tool。
+func (t *CalculatorTool) Description() string {
+	return "计算一个算术表达式，输入例如 \"(12 + 8) * 3 / 2\"，只支持 + - * / 和括号"
+}
+
+// Run 实现 Tool。
+func (t *CalculatorTool) Run(ctx context.Context, input string) (string, error) {
+	expr, err := parser.ParseExpr(input)
+	if err != nil {
+		return "", fmt.Errorf("无法解析表达式: %v", err)
+	}
+	result, err := evalExpr(expr)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v", result), nil
+}
+
+// evalExpr 递归求值一个仅包含数字字面量与 + - * / 的表达式树。
+func evalExpr(expr ast.Expr) (float64, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		var v float64
+		if _, err := fmt.Sscanf(e.Value, "%g", &v); err != nil {
+			return 0, fmt.Errorf("无法解析数字: %s", e.Value)
+		}
+		return v, nil
+	case *ast.ParenExpr:
+		return evalExpr(e.X)
+	case *ast.UnaryExpr:
+		v, err := evalExpr(e.X)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.SUB:
+			return -v, nil
+		case token.ADD:
+			return v, nil
+		default:
+			return 0, fmt.Errorf("不支持的运算符: %s", e.Op)
+		}
+	case *ast.BinaryExpr:
+		left, err := evalExpr(e.X)
+		if err != nil {
+			return 0, err
+		}
+		right, err := evalExpr(e.Y)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.ADD:
+			return left + right, nil
+		case token.SUB:
+			return left - right, nil
+		case token.MUL:
+			return left * right, nil
+		case token.QUO:
+			if right == 0 {
+				return 0, fmt.Errorf("除数不能为0")
+			}
+			return left / right, nil
+		default:
+			return 0, fmt.Errorf("不支持的运算符: %s", e.Op)
+		}
+	default:
+		return 0, fmt.Errorf("不支持的表达式")
+	}
+}
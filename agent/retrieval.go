@@ -0,0 +1,49 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// RetrievalTool 把本地向量库包装成一个智能体工具，
+// 用于回答知识库已经覆盖到的问题。
+type RetrievalTool struct {
+	Store vectorstores.VectorStore
+	TopK  int
+}
+
+// NewRetrievalTool 创建一个 RetrievalTool，topK 默认取5。
+func NewRetrievalTool(store vectorstores.VectorStore, topK int) *RetrievalTool {
+	if topK <= 0 {
+		topK = 5
+	}
+	return &RetrievalTool{Store: store, TopK: topK}
+}
+
+// Name 实现 Tool。
+func (t *RetrievalTool) Name() string { return "knowledge_base" }
+
+// Description 实现 Tool。
+func (t *RetrievalTool) Description() string {
+	return "在已摄取的本地知识库中检索相关段落，输入是待查询的问题"
+}
+
+// Run 实现 Tool。
+func (t *RetrievalTool) Run(ctx context.Context, input string) (string, error) {
+	results, err := t.Store.SimilaritySearch(ctx, input, t.TopK)
+	if err != nil {
+		return "", fmt.Errorf("检索知识库失败: %v", err)
+	}
+	if len(results) == 0 {
+		return "知识库中没有找到相关内容", nil
+	}
+
+	var out strings.Builder
+	for i, doc := range results {
+		out.WriteString(fmt.Sprintf("%d. %s\n", i+1, doc.PageContent))
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,16 @@
+// Package agent 实现一个 ReAct 风格的工具调用循环：LLM 在“查询本地知识库”、
+// “联网搜索”和“计算器”之间选择合适的工具，执行后把观察结果反馈给LLM，
+// 如此循环直到给出最终答案或达到步数上限。
+package agent
+
+import "context"
+
+// Tool 是智能体可以调用的外部能力的统一接口。
+type Tool interface {
+	// Name 是工具在 Action: 行里使用的标识符，必须唯一
+	Name() string
+	// Description 会被拼进提示词，告诉LLM这个工具能做什么、输入格式是什么
+	Description() string
+	// Run 执行工具调用，input 是LLM给出的 Action Input
+	Run(ctx context.Context, input string) (string, error)
+}
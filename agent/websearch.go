@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// WebSearchTool 通过 DuckDuckGo 的免密钥 Instant Answer API 做联网搜索，
+// 用于回答本地知识库没有覆盖的、需要时效性信息的问题。
+type WebSearchTool struct {
+	// Endpoint 默认指向 DuckDuckGo，可以替换为自建的 SearxNG 实例等兼容接口
+	Endpoint string
+}
+
+// NewWebSearchTool 创建一个使用默认 DuckDuckGo 接口的 WebSearchTool。
+func NewWebSearchTool() *WebSearchTool {
+	return &WebSearchTool{Endpoint: "https://api.duckduckgo.com/"}
+}
+
+// Name 实现 Tool。
+func (t *WebSearchTool) Name() string { return "web_search" }
+
+// Description 实现 Tool。
+func (t *WebSearchTool) Description() string {
+	return "联网搜索最新信息，输入是搜索关键词，返回摘要文本"
+}
+
+// duckduckgoResponse 只取我们用得到的字段。
+type duckduckgoResponse struct {
+	AbstractText  string `json:"AbstractText"`
+	Heading       string `json:"Heading"`
+	RelatedTopics []struct {
+		Text string `json:"Text"`
+	} `json:"RelatedTopics"`
+}
+
+// Run 实现 Tool。
+func (t *WebSearchTool) Run(ctx context.Context, input string) (string, error) {
+	endpoint := t.Endpoint
+	if endpoint == "" {
+		endpoint = "https://api.duckduckgo.com/"
+	}
+
+	reqURL := fmt.Sprintf("%s?q=%s&format=json&no_html=1&skip_disambig=1", endpoint, url.QueryEscape(input))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("联网搜索失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result duckduckgoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("解析搜索结果失败: %v", err)
+	}
+
+	var summary strings.Builder
+	if result.Heading != "" {
+		summary.WriteString(result.Heading + "\n")
+	}
+	if result.AbstractText != "" {
+		summary.WriteString(result.AbstractText + "\n")
+	}
+	for _, topic := range result.RelatedTopics {
+		if topic.Text != "" {
+			summary.WriteString(topic.Text + "\n")
+		}
+	}
+
+	if summary.Len() == 0 {
+		return "没有搜索到相关结果", nil
+	}
+	return summary.String(), nil
+}
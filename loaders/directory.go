@@ -0,0 +1,57 @@
+package loaders
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// DirectoryLoader 递归遍历本地目录，对每个匹配 Glob 模式的文件
+// 按其扩展名分发给对应的 DocumentLoader。
+type DirectoryLoader struct {
+	// Glob 是匹配文件名（不含目录部分）的模式，例如 "*.md"；为空时匹配所有文件。
+	// filepath.Match 不支持 "**" 且 "*" 不跨越路径分隔符，所以这里只匹配
+	// basename，目录的递归遍历由 filepath.WalkDir 负责。
+	Glob string
+}
+
+// Load 实现 DocumentLoader，遍历 source 指向的目录。
+func (l *DirectoryLoader) Load(ctx context.Context, source string) ([]schema.Document, error) {
+	var allDocs []schema.Document
+
+	err := filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if l.Glob != "" {
+			if matched, matchErr := filepath.Match(l.Glob, filepath.Base(path)); matchErr == nil && !matched {
+				return nil
+			}
+		}
+
+		loader, loaderErr := ForSource(path)
+		if loaderErr != nil {
+			return loaderErr
+		}
+		docs, loadErr := loader.Load(ctx, path)
+		if loadErr != nil {
+			fmt.Printf("加载文件 %s 失败: %v\n", path, loadErr)
+			return nil
+		}
+		allDocs = append(allDocs, docs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(allDocs) == 0 {
+		return nil, fmt.Errorf("目录 %s 下未找到任何可加载的文件", source)
+	}
+	return allDocs, nil
+}
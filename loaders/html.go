@@ -0,0 +1,53 @@
+package loaders
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// HTMLLoader 通过 HTTP(S) 抓取网页正文，等价于原来的 loadAndSplitWebContent
+// 中去除分块逻辑后的部分，额外附加页面标题元数据。
+type HTMLLoader struct{}
+
+// Load 实现 DocumentLoader，抓取 source 对应的网页并提取 body 文本。
+func (l *HTMLLoader) Load(ctx context.Context, source string) ([]schema.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(doc.Find("title").First().Text())
+
+	var content strings.Builder
+	doc.Find("script,style").Remove()
+	doc.Find("body").Each(func(i int, s *goquery.Selection) {
+		text := strings.TrimSpace(s.Text())
+		if text != "" {
+			content.WriteString(text)
+			content.WriteString("\n")
+		}
+	})
+
+	return []schema.Document{{
+		PageContent: content.String(),
+		Metadata: map[string]any{
+			"source": source,
+			"title":  title,
+		},
+	}}, nil
+}
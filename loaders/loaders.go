@@ -0,0 +1,44 @@
+// Package loaders 提供统一的文档加载抽象，支持从网页、PDF、Markdown、
+// 纯文本以及本地目录中读取内容并转换为带元数据的 schema.Document。
+package loaders
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// DocumentLoader 是所有具体加载器需要实现的统一接口。
+// Load 返回加载到的原始文档（尚未分块），调用方负责后续的切分。
+type DocumentLoader interface {
+	// Load 从 source 指定的位置读取内容并构造文档列表
+	Load(ctx context.Context, source string) ([]schema.Document, error)
+}
+
+// ForSource 根据来源的协议前缀或文件扩展名选择合适的 DocumentLoader。
+// 支持 http(s):// URL、file:// 本地文件、*.pdf、*.md/*.markdown、
+// 以及普通目录路径（递归遍历）。
+func ForSource(source string) (DocumentLoader, error) {
+	switch {
+	case strings.HasPrefix(source, "http://"), strings.HasPrefix(source, "https://"):
+		return &HTMLLoader{}, nil
+	case strings.HasPrefix(source, "file://"):
+		return ForSource(strings.TrimPrefix(source, "file://"))
+	case strings.HasSuffix(strings.ToLower(source), ".pdf"):
+		return &PDFLoader{}, nil
+	case strings.HasSuffix(strings.ToLower(source), ".md"), strings.HasSuffix(strings.ToLower(source), ".markdown"):
+		return &MarkdownLoader{}, nil
+	case isDir(source):
+		return &DirectoryLoader{}, nil
+	default:
+		return &TextLoader{}, nil
+	}
+}
+
+// isDir 判断 source 是否是一个已存在的本地目录。
+func isDir(source string) bool {
+	info, err := os.Stat(source)
+	return err == nil && info.IsDir()
+}
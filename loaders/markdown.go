@@ -0,0 +1,73 @@
+package loaders
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// MarkdownLoader 按标题层级拆分 Markdown 文件，每个最小标题段落生成一个
+// Document，并在元数据中记录完整的标题路径（heading path），
+// 例如 "介绍 > 快速开始 > 安装"。
+type MarkdownLoader struct{}
+
+// Load 实现 DocumentLoader，读取 source 指向的 Markdown 文件。
+func (l *MarkdownLoader) Load(ctx context.Context, source string) ([]schema.Document, error) {
+	raw, err := os.ReadFile(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []schema.Document
+	var headingStack []string
+	var body strings.Builder
+
+	flush := func() {
+		content := strings.TrimSpace(body.String())
+		if content == "" {
+			return
+		}
+		documents = append(documents, schema.Document{
+			PageContent: content,
+			Metadata: map[string]any{
+				"source":       source,
+				"heading_path": strings.Join(headingStack, " > "),
+			},
+		})
+		body.Reset()
+	}
+
+	for _, line := range strings.Split(string(raw), "\n") {
+		if level, title, ok := parseHeading(line); ok {
+			flush()
+			if level-1 < len(headingStack) {
+				headingStack = headingStack[:level-1]
+			}
+			for len(headingStack) < level-1 {
+				headingStack = append(headingStack, "")
+			}
+			headingStack = append(headingStack[:level-1], title)
+			continue
+		}
+		body.WriteString(line)
+		body.WriteString("\n")
+	}
+	flush()
+
+	return documents, nil
+}
+
+// parseHeading 判断一行文本是否是 Markdown 标题（# ~ ######），
+// 返回标题级别和去除井号后的标题文本。
+func parseHeading(line string) (level int, title string, ok bool) {
+	trimmed := strings.TrimSpace(line)
+	for level < 6 && level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level >= len(trimmed) || trimmed[level] != ' ' {
+		return 0, "", false
+	}
+	return level, strings.TrimSpace(trimmed[level+1:]), true
+}
@@ -0,0 +1,30 @@
+package loaders
+
+import (
+	"context"
+	"os"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// TextLoader 读取纯文本文件，附加文件修改时间元数据。
+type TextLoader struct{}
+
+// Load 实现 DocumentLoader，读取 source 指向的文本文件。
+func (l *TextLoader) Load(ctx context.Context, source string) ([]schema.Document, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(source)
+	if err != nil {
+		return nil, err
+	}
+	return []schema.Document{{
+		PageContent: string(raw),
+		Metadata: map[string]any{
+			"source": source,
+			"mtime":  info.ModTime(),
+		},
+	}}, nil
+}
@@ -0,0 +1,121 @@
+// Package memory 为交互式问答提供多轮对话记忆：保留最近 N 轮问答，
+// 并在检索前用LLM把当前问题改写成独立完整的问题（"condense question"模式），
+// 这样"第二条说的是什么？"这类追问也能正确召回相关文档。
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// thinkBlockRE 匹配推理模型（如 deepseek-r1）输出里夹带的 <think>...</think>
+// 思考过程，改写结果在喂给检索前需要先把它剥离。
+var thinkBlockRE = regexp.MustCompile(`(?s)<think>.*?</think>`)
+
+// condensePromptTemplate 让LLM结合历史对话把当前问题改写成一个不依赖上下文、
+// 可以独立用于检索的完整问题。
+const condensePromptTemplate = "以下是到目前为止的对话历史：\n\n%s\n" +
+	"根据以上历史，把用户的最新问题改写为一个不依赖上下文、语义完整的独立问题。" +
+	"只输出改写后的问题本身，不要输出任何解释。\n\n最新问题：%s"
+
+// Turn 记录一轮问答。
+type Turn struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// Memory 保存最近 MaxTurns 轮对话，并支持持久化到磁盘。
+type Memory struct {
+	path     string
+	maxTurns int
+	turns    []Turn
+}
+
+// New 创建一个 Memory，如果 path 指向的文件已存在则从中加载历史。
+func New(path string, maxTurns int) (*Memory, error) {
+	m := &Memory{path: path, maxTurns: maxTurns}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("读取对话历史失败: %v", err)
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &m.turns); err != nil {
+			return nil, fmt.Errorf("解析对话历史失败: %v", err)
+		}
+	}
+	return m, nil
+}
+
+// History 返回当前保留的对话轮次，从旧到新排列。
+func (m *Memory) History() []Turn {
+	return m.turns
+}
+
+// Add 追加一轮问答，超过 MaxTurns 时丢弃最旧的一轮。
+func (m *Memory) Add(question, answer string) {
+	m.turns = append(m.turns, Turn{Question: question, Answer: answer})
+	if m.maxTurns > 0 && len(m.turns) > m.maxTurns {
+		m.turns = m.turns[len(m.turns)-m.maxTurns:]
+	}
+}
+
+// Reset 清空内存中的对话历史（不影响磁盘上的文件，需要调用 Save 才会落盘）。
+func (m *Memory) Reset() {
+	m.turns = nil
+}
+
+// Save 把当前对话历史写入磁盘。
+func (m *Memory) Save() error {
+	raw, err := json.MarshalIndent(m.turns, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化对话历史失败: %v", err)
+	}
+	if err := os.WriteFile(m.path, raw, 0o644); err != nil {
+		return fmt.Errorf("写入对话历史文件失败: %v", err)
+	}
+	return nil
+}
+
+// CondenseQuestion 结合历史对话，把 question 改写为一个独立完整的问题，
+// 供后续检索使用。没有历史时直接返回原问题，不产生多余的LLM调用。
+func (m *Memory) CondenseQuestion(ctx context.Context, llm llms.Model, question string) (string, error) {
+	if len(m.turns) == 0 {
+		return question, nil
+	}
+
+	prompt := fmt.Sprintf(condensePromptTemplate, formatHistory(m.turns), question)
+	standalone, err := llms.GenerateFromSinglePrompt(ctx, llm, prompt, llms.WithTemperature(0))
+	if err != nil {
+		return "", fmt.Errorf("改写问题失败: %v", err)
+	}
+	return stripReasoning(standalone), nil
+}
+
+// stripReasoning 去掉推理模型输出里的 <think>...</think> 块，只保留真正的
+// 改写结果；剥离后为空则退化为原始输出，避免误伤不带思考过程的模型。
+func stripReasoning(text string) string {
+	stripped := strings.TrimSpace(thinkBlockRE.ReplaceAllString(text, ""))
+	if stripped == "" {
+		return strings.TrimSpace(text)
+	}
+	return stripped
+}
+
+// formatHistory 把历史对话渲染成供 condense 提示词使用的纯文本。
+func formatHistory(turns []Turn) string {
+	var out strings.Builder
+	for i, t := range turns {
+		out.WriteString(fmt.Sprintf("%d. 用户：%s\n   助手：%s\n", i+1, t.Question, t.Answer))
+	}
+	return out.String()
+}
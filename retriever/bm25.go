@@ -0,0 +1,125 @@
+package retriever
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// bm25k1、bm25b 是经典 Okapi BM25 的平滑参数，取常用的经验值。
+const (
+	bm25k1 = 1.2
+	bm25b  = 0.75
+)
+
+// BM25Index 是建立在同一批切分后的文档之上的关键词索引，
+// 用于在向量检索之外提供基于词频的召回通道。
+type BM25Index struct {
+	docs      []schema.Document
+	termFreqs []map[string]int
+	docLens   []int
+	avgDocLen float64
+	docFreq   map[string]int
+}
+
+// NewBM25Index 对 docs 建立倒排词频统计，docs 的下标即后续排名中的文档编号。
+func NewBM25Index(docs []schema.Document) *BM25Index {
+	idx := &BM25Index{
+		docs:    docs,
+		docFreq: make(map[string]int),
+	}
+
+	var totalLen int
+	for _, doc := range docs {
+		terms := tokenize(doc.PageContent)
+		freq := make(map[string]int, len(terms))
+		seen := make(map[string]bool, len(terms))
+		for _, t := range terms {
+			freq[t]++
+			if !seen[t] {
+				idx.docFreq[t]++
+				seen[t] = true
+			}
+		}
+		idx.termFreqs = append(idx.termFreqs, freq)
+		idx.docLens = append(idx.docLens, len(terms))
+		totalLen += len(terms)
+	}
+	if len(docs) > 0 {
+		idx.avgDocLen = float64(totalLen) / float64(len(docs))
+	}
+	return idx
+}
+
+// Search 返回 query 在索引中的 BM25 排名，按分数从高到低排序，最多 topK 条。
+func (idx *BM25Index) Search(query string, topK int) []RankedDoc {
+	queryTerms := tokenize(query)
+
+	scores := make([]float64, len(idx.docs))
+	for _, term := range queryTerms {
+		df := idx.docFreq[term]
+		if df == 0 {
+			continue
+		}
+		idf := idfScore(len(idx.docs), df)
+		for i, freq := range idx.termFreqs {
+			tf := float64(freq[term])
+			if tf == 0 {
+				continue
+			}
+			norm := 1 - bm25b + bm25b*float64(idx.docLens[i])/idx.avgDocLen
+			scores[i] += idf * (tf * (bm25k1 + 1)) / (tf + bm25k1*norm)
+		}
+	}
+
+	ranked := make([]RankedDoc, 0, len(idx.docs))
+	for i, score := range scores {
+		if score <= 0 {
+			continue
+		}
+		ranked = append(ranked, RankedDoc{Document: idx.docs[i], Score: score})
+	}
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].Score > ranked[j].Score })
+	if len(ranked) > topK {
+		ranked = ranked[:topK]
+	}
+	return ranked
+}
+
+// idfScore 是 BM25 常用的平滑逆文档频率公式 log(1 + (N-df+0.5)/(df+0.5))，
+// 结果恒为正，出现在大多数文档中的词也不会产生负分。
+func idfScore(totalDocs, docFreq int) float64 {
+	return math.Log(1 + (float64(totalDocs-docFreq)+0.5)/(float64(docFreq)+0.5))
+}
+
+// tokenize 对中英文混合文本分词：英文/数字按单词切分，
+// 汉字逐字切分，这样即便没有空格也能建立有效的词频统计。
+// 日文假名、韩文谚文等其他字母按连续的一段作为一个词。
+func tokenize(text string) []string {
+	var tokens []string
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, strings.ToLower(current.String()))
+			current.Reset()
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			current.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
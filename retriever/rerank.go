@@ -0,0 +1,73 @@
+package retriever
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// rerankPromptTemplate 要求LLM对候选文档与查询的相关性打0-10分，
+// 只返回一个数字，方便程序解析，类似 LLMChainExtractor 的打分思路。
+const rerankPromptTemplate = "给定用户问题和一段候选文档，评估该文档与问题的相关程度，" +
+	"只输出一个0到10之间的整数分数，不要输出其他任何内容。\n\n问题：%s\n\n候选文档：%s"
+
+// rerank 对融合后的候选逐条调用LLM打分，丢弃低于 threshold 的文档，
+// 并按分数从高到低重新排序。
+func rerank(ctx context.Context, llm llms.Model, query string, candidates []schema.Document, threshold float64) ([]schema.Document, error) {
+	type scoredDoc struct {
+		doc   schema.Document
+		score float64
+	}
+
+	scored := make([]scoredDoc, 0, len(candidates))
+	for _, doc := range candidates {
+		score, err := scoreRelevance(ctx, llm, query, doc.PageContent)
+		if err != nil {
+			return nil, err
+		}
+		if score < threshold {
+			continue
+		}
+		scored = append(scored, scoredDoc{doc: doc, score: score})
+	}
+
+	sort.Slice(scored, func(i, j int) bool { return scored[i].score > scored[j].score })
+
+	result := make([]schema.Document, len(scored))
+	for i, s := range scored {
+		result[i] = s.doc
+	}
+	return result, nil
+}
+
+// trailingScoreRE 匹配响应里最后出现的一个整数，用来从推理模型（如
+// deepseek-r1）夹带的 <think>...</think> 思考文本中提取真正的打分。
+var trailingScoreRE = regexp.MustCompile(`-?\d+`)
+
+// scoreRelevance 向LLM发送一次打分请求并解析出0-10的分数。
+func scoreRelevance(ctx context.Context, llm llms.Model, query, content string) (float64, error) {
+	prompt := fmt.Sprintf(rerankPromptTemplate, query, content)
+
+	resp, err := llms.GenerateFromSinglePrompt(ctx, llm, prompt, llms.WithTemperature(0))
+	if err != nil {
+		return 0, fmt.Errorf("调用重排序模型失败: %v", err)
+	}
+
+	text := strings.TrimSpace(resp)
+	matches := trailingScoreRE.FindAllString(text, -1)
+	if len(matches) == 0 {
+		// 模型没有输出任何数字，退化为保留该候选，避免因解析失败丢弃有效结果
+		return 10, nil
+	}
+	score, err := strconv.ParseFloat(matches[len(matches)-1], 64)
+	if err != nil {
+		return 10, nil
+	}
+	return score, nil
+}
@@ -0,0 +1,146 @@
+// Package retriever 在向量相似度检索之外叠加了 BM25 关键词检索，
+// 通过 Reciprocal Rank Fusion 融合两路排名，并可选接入一个重排序阶段，
+// 对应 LangChain 生态里 ContextualCompressionRetriever 的思路。
+package retriever
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// rrfK 是 Reciprocal Rank Fusion 公式里的平滑常数，
+// 经验取值 60，排名靠前的文档贡献的分数差异更大。
+const rrfK = 60
+
+// RankedDoc 是单一排序通道（BM25 或向量检索）给出的带分数结果。
+type RankedDoc struct {
+	Document schema.Document
+	Score    float64
+}
+
+// Options 控制混合检索的召回宽度、最终返回条数以及是否开启重排序。
+type Options struct {
+	// TopK 是融合并（可选）重排后最终返回的文档条数
+	TopK int
+	// FetchK 是向量检索与BM25检索各自召回的候选条数，应大于等于 TopK
+	FetchK int
+	// Rerank 控制是否对融合后的候选做LLM打分重排
+	Rerank bool
+	// RerankThreshold 是重排序阶段的最低分数(0-10)，低于该分数的候选会被丢弃
+	RerankThreshold float64
+}
+
+// DefaultOptions 返回一组适合大多数场景的默认参数。
+func DefaultOptions() Options {
+	return Options{TopK: 5, FetchK: 20, RerankThreshold: 5}
+}
+
+// Retriever 组合向量存储与 BM25 索引，对外提供统一的混合检索入口。
+type Retriever struct {
+	store vectorstores.VectorStore
+	bm25  *BM25Index
+	llm   llms.Model
+	opts  Options
+}
+
+// New 创建一个 Retriever。bm25Docs 应是摄取阶段切分出的全部文档块，
+// 用来离线建立关键词索引；llm 仅在 opts.Rerank 为 true 时使用，可以为 nil。
+func New(vectorStore vectorstores.VectorStore, bm25Docs []schema.Document, llm llms.Model, opts Options) *Retriever {
+	return &Retriever{
+		store: vectorStore,
+		bm25:  NewBM25Index(bm25Docs),
+		llm:   llm,
+		opts:  opts,
+	}
+}
+
+// Retrieve 执行混合检索：先分别从向量库和BM25索引召回 FetchK 条候选，
+// 再用 RRF 融合排名，最后按需做一次LLM重排，返回最多 TopK 条文档。
+func (r *Retriever) Retrieve(ctx context.Context, query string) ([]schema.Document, error) {
+	vectorResults, err := r.store.SimilaritySearch(ctx, query, r.opts.FetchK)
+	if err != nil {
+		return nil, fmt.Errorf("向量检索失败: %v", err)
+	}
+	bm25Results := r.bm25.Search(query, r.opts.FetchK)
+
+	fused := fuse(vectorResults, bm25Results)
+	if len(fused) > r.opts.FetchK {
+		fused = fused[:r.opts.FetchK]
+	}
+
+	if r.opts.Rerank && r.llm != nil {
+		fused, err = rerank(ctx, r.llm, query, fused, r.opts.RerankThreshold)
+		if err != nil {
+			return nil, fmt.Errorf("重排序失败: %v", err)
+		}
+	}
+
+	if len(fused) > r.opts.TopK {
+		fused = fused[:r.opts.TopK]
+	}
+	return fused, nil
+}
+
+// fuse 用 Reciprocal Rank Fusion 合并向量检索和BM25检索的两路排名：
+// score(d) = Σ 1/(k + rank_i(d))，rank 从1开始计数。
+func fuse(vectorResults []schema.Document, bm25Results []RankedDoc) []schema.Document {
+	type entry struct {
+		doc   schema.Document
+		score float64
+	}
+
+	scores := make(map[string]*entry)
+	addRanked := func(docs []schema.Document) {
+		for rank, doc := range docs {
+			key := docKey(doc)
+			if e, ok := scores[key]; ok {
+				e.score += 1.0 / float64(rrfK+rank+1)
+				continue
+			}
+			scores[key] = &entry{doc: doc, score: 1.0 / float64(rrfK+rank+1)}
+		}
+	}
+
+	addRanked(vectorResults)
+	bm25Docs := make([]schema.Document, len(bm25Results))
+	for i, r := range bm25Results {
+		bm25Docs[i] = r.Document
+	}
+	addRanked(bm25Docs)
+
+	merged := make([]*entry, 0, len(scores))
+	var maxScore float64
+	for _, e := range scores {
+		merged = append(merged, e)
+		if e.score > maxScore {
+			maxScore = e.score
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].score > merged[j].score })
+
+	// 把RRF分数归一化到[0,1]后按"Score是距离、相似度=1-Score"的既有约定写回
+	// doc.Score，这样BM25命中的文档也能有一个真实分数，而不是停留在零值
+	// 被误判成最高相似度。
+	result := make([]schema.Document, len(merged))
+	for i, e := range merged {
+		doc := e.doc
+		if maxScore > 0 {
+			doc.Score = float32(1 - e.score/maxScore)
+		}
+		result[i] = doc
+	}
+	return result
+}
+
+// docKey 生成文档的去重键，优先使用来源+分块编号，没有元数据时退化为全文内容。
+func docKey(doc schema.Document) string {
+	if source, ok := doc.Metadata["source"]; ok {
+		return fmt.Sprintf("%v#%v", source, doc.Metadata["chunk"])
+	}
+	return doc.PageContent
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// generate 把检索结果拼成系统提示词，调用LLM生成回答。streamFunc 非空时，
+// 每个token都会在生成过程中回调给调用方，用于 SSE 流式返回；
+// 无论是否流式，都会返回完整的回答文本。
+func generate(ctx context.Context, llm llms.Model, question string, results []schema.Document, streamFunc func(chunk string)) (string, error) {
+	var references strings.Builder
+	for i, doc := range results {
+		score := 1 - doc.Score
+		references.WriteString(fmt.Sprintf("%d. [相似度：%f] %s\n", i+1, score, doc.PageContent))
+	}
+
+	messages := []llms.MessageContent{
+		{
+			Role: llms.ChatMessageTypeSystem,
+			Parts: []llms.ContentPart{
+				llms.TextContent{
+					Text: fmt.Sprintf(
+						"你是一个专业的知识库问答助手。以下是基于向量相似度检索到的相关文档：\n\n%s\n"+
+							"请基于以上参考信息回答用户问题。回答时请注意：\n"+
+							"1. 优先使用相关度更高的参考信息\n"+
+							"2. 如果参考信息不足以完整回答问题，请明确指出",
+						references.String(),
+					),
+				},
+			},
+		},
+		{
+			Role: llms.ChatMessageTypeHuman,
+			Parts: []llms.ContentPart{
+				llms.TextContent{Text: question},
+			},
+		},
+	}
+
+	var answer strings.Builder
+	_, err := llm.GenerateContent(
+		ctx,
+		messages,
+		llms.WithTemperature(0.8),
+		llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			answer.Write(chunk)
+			if streamFunc != nil {
+				streamFunc(string(chunk))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return "", fmt.Errorf("生成回答失败: %v", err)
+	}
+	return answer.String(), nil
+}
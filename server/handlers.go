@@ -0,0 +1,192 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/tmc/langchaingo/schema"
+	"github.com/togettoyou/rag-demo/retriever"
+)
+
+// ingestRequest 是 POST /ingest 的请求体：可以混合传入URL/文件路径列表，
+// 也可以直接传入一段原始文本。
+type ingestRequest struct {
+	Sources []string `json:"sources"`
+	Text    string   `json:"text"`
+}
+
+// ingestResponse 汇总了本次摄取写入的文档块总数。
+type ingestResponse struct {
+	ChunksAdded int `json:"chunks_added"`
+}
+
+// handleIngest 实现 POST /ingest，支持 JSON 请求体中的 sources/text，
+// 以及 multipart/form-data 中名为 "file" 的文件上传。
+func (s *Server) handleIngest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	total := 0
+
+	contentType := r.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("读取上传文件失败: %v", err))
+			return
+		}
+		defer file.Close()
+
+		raw, err := io.ReadAll(file)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		n, err := s.ingestText(ctx, header.Filename, string(raw))
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		total += n
+	} else {
+		var req ingestRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("解析请求体失败: %v", err))
+			return
+		}
+		for _, source := range req.Sources {
+			n, err := s.ingestSource(ctx, source)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, fmt.Errorf("摄取 %s 失败: %v", source, err))
+				return
+			}
+			total += n
+		}
+		if req.Text != "" {
+			n, err := s.ingestText(ctx, "inline-text", req.Text)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			total += n
+		}
+	}
+
+	writeJSON(w, http.StatusOK, ingestResponse{ChunksAdded: total})
+}
+
+// queryRequest 是 POST /query 的请求体；GET /query/stream 不使用它，
+// 而是从 URL 查询参数 question 读取问题。
+type queryRequest struct {
+	Question string `json:"question"`
+}
+
+// citation 是返回给调用方的引用信息，只暴露来源、分块编号和正文前100字节的摘要，
+// 不包含完整正文和相似度。
+type citation struct {
+	Source  string `json:"source"`
+	Chunk   string `json:"chunk"`
+	Excerpt string `json:"excerpt"`
+}
+
+// queryResponse 是 POST /query 的响应体：回答正文加引用列表。
+type queryResponse struct {
+	Answer    string     `json:"answer"`
+	Citations []citation `json:"citations"`
+}
+
+// handleQuery 实现 POST /query，一次性返回完整回答和引用列表。
+func (s *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
+	var req queryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("解析请求体失败: %v", err))
+		return
+	}
+
+	answer, results, err := s.answer(r.Context(), req.Question)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, queryResponse{Answer: answer, Citations: toCitations(results)})
+}
+
+// handleQueryStream 实现 GET /query/stream，通过 Server-Sent Events
+// 把LLM生成的token逐个推送给客户端，结束时发送一个 "[DONE]" 事件。
+func (s *Server) handleQueryStream(w http.ResponseWriter, r *http.Request) {
+	question := r.URL.Query().Get("question")
+	if question == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("缺少 question 参数"))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("当前响应不支持流式输出"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	retr := retriever.New(s.vectorStore, s.corpus, s.llm, s.retrieverOpts)
+	results, err := retr.Retrieve(r.Context(), question)
+	if err != nil {
+		fmt.Fprintf(w, "event: error\ndata: %s\n\n", err.Error())
+		flusher.Flush()
+		return
+	}
+
+	_, err = generate(r.Context(), s.llm, question, results, func(chunk string) {
+		writeQueryStreamChunk(w, flusher, chunk)
+	})
+	if err != nil {
+		fmt.Fprintf(w, "event: error\ndata: %s\n\n", err.Error())
+		flusher.Flush()
+		return
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	flusher.Flush()
+}
+
+// queryStreamChunk 是 GET /query/stream 每个 data 事件的载荷，JSON 编码
+// chunk 是为了避免 LLM 输出里的换行把 SSE 事件截断。
+type queryStreamChunk struct {
+	Chunk string `json:"chunk"`
+}
+
+// writeQueryStreamChunk 把一段LLM输出JSON编码后写成一个 data: 事件。
+func writeQueryStreamChunk(w http.ResponseWriter, flusher http.Flusher, chunk string) {
+	raw, _ := json.Marshal(queryStreamChunk{Chunk: chunk})
+	fmt.Fprintf(w, "data: %s\n\n", raw)
+	flusher.Flush()
+}
+
+func toCitations(results []schema.Document) []citation {
+	citations := make([]citation, len(results))
+	for i, doc := range results {
+		excerpt := doc.PageContent
+		if len(excerpt) > 100 {
+			excerpt = excerpt[:100] + "..."
+		}
+		citations[i] = citation{
+			Source:  fmt.Sprintf("%v", doc.Metadata["source"]),
+			Chunk:   fmt.Sprintf("%v", doc.Metadata["chunk"]),
+			Excerpt: excerpt,
+		}
+	}
+	return citations
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/togettoyou/rag-demo/retriever"
+)
+
+// chatCompletionRequest 是 OpenAI /v1/chat/completions 请求体的最小子集，
+// 只取最后一条用户消息作为问题，足以让现有的聊天前端直接对接。
+type chatCompletionRequest struct {
+	Model    string `json:"model"`
+	Messages []struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	} `json:"messages"`
+	Stream bool `json:"stream"`
+}
+
+// chatCompletionChoice 与 chatCompletionResponse 对应 OpenAI 响应结构里
+// 我们实际用到的字段。
+type chatCompletionChoice struct {
+	Index   int `json:"index"`
+	Message struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	} `json:"message"`
+	FinishReason string `json:"finish_reason"`
+}
+
+type chatCompletionResponse struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []chatCompletionChoice `json:"choices"`
+}
+
+// handleChatCompletions 实现一个最小的 OpenAI 兼容 /v1/chat/completions，
+// 内部复用 RAG 流程：把最后一条用户消息当作问题去检索并生成回答。
+func (s *Server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	var req chatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("解析请求体失败: %v", err))
+		return
+	}
+
+	question := lastUserMessage(req)
+	if question == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("messages 中没有用户消息"))
+		return
+	}
+
+	if req.Stream {
+		s.streamChatCompletion(w, r, req.Model, question)
+		return
+	}
+
+	answer, _, err := s.answer(r.Context(), question)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := chatCompletionResponse{
+		ID:      fmt.Sprintf("chatcmpl-%d", time.Now().UnixNano()),
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   req.Model,
+	}
+	choice := chatCompletionChoice{FinishReason: "stop"}
+	choice.Message.Role = "assistant"
+	choice.Message.Content = answer
+	resp.Choices = []chatCompletionChoice{choice}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// streamChatCompletion 以 OpenAI 流式格式（delta chunk + [DONE]）返回回答。
+func (s *Server) streamChatCompletion(w http.ResponseWriter, r *http.Request, model, question string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("当前响应不支持流式输出"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	retr := retriever.New(s.vectorStore, s.corpus, s.llm, s.retrieverOpts)
+	results, err := retr.Retrieve(r.Context(), question)
+	if err != nil {
+		writeSSEChunk(w, flusher, model, "", "stop")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+		flusher.Flush()
+		return
+	}
+
+	_, err = generate(r.Context(), s.llm, question, results, func(chunk string) {
+		writeSSEChunk(w, flusher, model, chunk, "")
+	})
+	finishReason := "stop"
+	if err != nil {
+		finishReason = "error"
+	}
+	writeSSEChunk(w, flusher, model, "", finishReason)
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	flusher.Flush()
+}
+
+// writeSSEChunk 按 OpenAI 的流式分片格式写出一个 data: 事件。
+func writeSSEChunk(w http.ResponseWriter, flusher http.Flusher, model, content, finishReason string) {
+	type delta struct {
+		Content string `json:"content,omitempty"`
+	}
+	type choice struct {
+		Index        int    `json:"index"`
+		Delta        delta  `json:"delta"`
+		FinishReason string `json:"finish_reason,omitempty"`
+	}
+	chunk := struct {
+		ID      string   `json:"id"`
+		Object  string   `json:"object"`
+		Created int64    `json:"created"`
+		Model   string   `json:"model"`
+		Choices []choice `json:"choices"`
+	}{
+		ID:      fmt.Sprintf("chatcmpl-%d", time.Now().UnixNano()),
+		Object:  "chat.completion.chunk",
+		Created: time.Now().Unix(),
+		Model:   model,
+		Choices: []choice{{Delta: delta{Content: content}, FinishReason: finishReason}},
+	}
+	raw, _ := json.Marshal(chunk)
+	fmt.Fprintf(w, "data: %s\n\n", raw)
+	flusher.Flush()
+}
+
+// lastUserMessage 从消息列表里取出最后一条 role=user 的内容。
+func lastUserMessage(req chatCompletionRequest) string {
+	for i := len(req.Messages) - 1; i >= 0; i-- {
+		if req.Messages[i].Role == "user" {
+			return req.Messages[i].Content
+		}
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+// Package server 把 RAG 流程包装成一个 HTTP 服务：提供 /ingest 摄取接口、
+// /query 与 /query/stream 问答接口，以及一个兼容 OpenAI 的
+// /v1/chat/completions 接口，方便已有的聊天前端直接对接。
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/textsplitter"
+	"github.com/togettoyou/rag-demo/loaders"
+	"github.com/togettoyou/rag-demo/retriever"
+	"github.com/togettoyou/rag-demo/store"
+)
+
+// Server 持有 RAG 流程所需的全部组件，通过 Routes 暴露为标准的 http.Handler。
+type Server struct {
+	vectorStore   store.Store
+	llm           llms.Model
+	retrieverOpts retriever.Options
+	corpus        []schema.Document // 已摄取的全部文档块，用于重建BM25索引
+}
+
+// New 创建一个 Server。retrieverOpts 用于控制每次请求的混合检索参数。
+func New(vectorStore store.Store, llm llms.Model, retrieverOpts retriever.Options) *Server {
+	return &Server{
+		vectorStore:   vectorStore,
+		llm:           llm,
+		retrieverOpts: retrieverOpts,
+	}
+}
+
+// Routes 注册所有 HTTP 路由。
+func (s *Server) Routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /ingest", s.handleIngest)
+	mux.HandleFunc("POST /query", s.handleQuery)
+	mux.HandleFunc("GET /query/stream", s.handleQueryStream)
+	mux.HandleFunc("POST /v1/chat/completions", s.handleChatCompletions)
+	return mux
+}
+
+// splitIntoDocuments 把原始文档统一切分为512字符的块，并继承原始元数据。
+func splitIntoDocuments(rawDocs []schema.Document) ([]schema.Document, error) {
+	splitter := textsplitter.NewRecursiveCharacter(
+		textsplitter.WithChunkSize(512),
+		textsplitter.WithChunkOverlap(0),
+	)
+
+	var documents []schema.Document
+	for _, rawDoc := range rawDocs {
+		chunks, err := splitter.SplitText(rawDoc.PageContent)
+		if err != nil {
+			return nil, err
+		}
+		for i, chunk := range chunks {
+			metadata := map[string]any{"chunk": fmt.Sprintf("%d", i)}
+			for k, v := range rawDoc.Metadata {
+				metadata[k] = v
+			}
+			documents = append(documents, schema.Document{PageContent: chunk, Metadata: metadata})
+		}
+	}
+	return documents, nil
+}
+
+// ingestSource 加载并切分一个来源（URL或本地路径），追加到服务的语料库中，
+// 同时写入向量存储，供后续问答检索使用。
+func (s *Server) ingestSource(ctx context.Context, source string) (int, error) {
+	loader, err := loaders.ForSource(source)
+	if err != nil {
+		return 0, err
+	}
+	rawDocs, err := loader.Load(ctx, source)
+	if err != nil {
+		return 0, err
+	}
+	docs, err := splitIntoDocuments(rawDocs)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := s.vectorStore.AddDocuments(ctx, docs); err != nil {
+		return 0, fmt.Errorf("写入向量存储失败: %v", err)
+	}
+	s.corpus = append(s.corpus, docs...)
+	return len(docs), nil
+}
+
+// ingestText 把一段原始文本（例如上传的文件内容）当作一个来源摄取。
+func (s *Server) ingestText(ctx context.Context, source, text string) (int, error) {
+	docs, err := splitIntoDocuments([]schema.Document{{
+		PageContent: text,
+		Metadata:    map[string]any{"source": source},
+	}})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := s.vectorStore.AddDocuments(ctx, docs); err != nil {
+		return 0, fmt.Errorf("写入向量存储失败: %v", err)
+	}
+	s.corpus = append(s.corpus, docs...)
+	return len(docs), nil
+}
+
+// answer 对 question 执行一次完整的检索+生成，返回回答正文与引用到的文档。
+func (s *Server) answer(ctx context.Context, question string) (string, []schema.Document, error) {
+	r := retriever.New(s.vectorStore, s.corpus, s.llm, s.retrieverOpts)
+	results, err := r.Retrieve(ctx, question)
+	if err != nil {
+		return "", nil, fmt.Errorf("检索失败: %v", err)
+	}
+	if len(results) == 0 {
+		return "未找到相关的参考信息，请换个问题试试。", nil, nil
+	}
+
+	text, err := generate(ctx, s.llm, question, results, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	return text, results, nil
+}
@@ -0,0 +1,142 @@
+package splitter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// chineseTerminators 是中文里常见的句子终止符号，沿用 langchain-ChatGLM
+// 生态里 ChineseTextSplitter 的思路：句号、感叹号、问号和省略号。
+const chineseTerminators = "。！？…"
+
+// collapseWhitespaceRE 把连续的空格/制表符压缩成一个空格，PDF抽取出的文本
+// 经常夹杂大量多余空白，压缩后句子边界判断才准确。
+var collapseWhitespaceRE = regexp.MustCompile(`[ \t]+`)
+
+// collapseBlankLinesRE 把三个及以上的换行压缩成两个，统一段落分隔符。
+var collapseBlankLinesRE = regexp.MustCompile(`\n{3,}`)
+
+// ChineseSplitter 先按标题、段落和中文句子终止符切分出句子，
+// 再贪心地把句子打包成以 SentenceSize 字符为目标长度的块，块之间保留 Overlap
+// 个字符的滑动重叠，避免上下文在块边界被截断。句子本身不会被截断：单个句子
+// （连同块开头的重叠文本）超过 SentenceSize 时，该块会超出 SentenceSize。
+type ChineseSplitter struct {
+	SentenceSize int
+	Overlap      int
+}
+
+// NewChineseSplitter 创建一个 ChineseSplitter，sentenceSize<=0 时使用默认值250。
+func NewChineseSplitter(sentenceSize, overlap int) *ChineseSplitter {
+	if sentenceSize <= 0 {
+		sentenceSize = DefaultSentenceSize
+	}
+	return &ChineseSplitter{SentenceSize: sentenceSize, Overlap: overlap}
+}
+
+// SplitText 实现 textsplitter.TextSplitter。
+func (s *ChineseSplitter) SplitText(text string) ([]string, error) {
+	sentences := splitIntoSentences(text)
+	return packSentences(sentences, s.SentenceSize, s.Overlap), nil
+}
+
+// splitIntoSentences 把文本按段落、标题行和中文句子终止符切成句子列表。
+func splitIntoSentences(text string) []string {
+	text = normalizeWhitespace(text)
+
+	var sentences []string
+	for _, para := range strings.Split(text, "\n\n") {
+		para = strings.TrimSpace(para)
+		// 段落开头的标题行各自单独成句，紧跟其后的正文继续按句子终止符切分
+		for isMarkdownHeading(para) {
+			heading, rest, _ := strings.Cut(para, "\n")
+			sentences = append(sentences, strings.TrimSpace(heading))
+			para = strings.TrimSpace(rest)
+		}
+		if para == "" {
+			continue
+		}
+		sentences = append(sentences, splitParagraphIntoSentences(para)...)
+	}
+	return sentences
+}
+
+// splitParagraphIntoSentences 在一个段落内部按中文句子终止符切分，
+// 终止符（含连续的省略号等）保留在前一句的末尾。
+func splitParagraphIntoSentences(para string) []string {
+	runes := []rune(para)
+	var sentences []string
+	start := 0
+	i := 0
+	for i < len(runes) {
+		if strings.ContainsRune(chineseTerminators, runes[i]) {
+			j := i + 1
+			for j < len(runes) && strings.ContainsRune(chineseTerminators, runes[j]) {
+				j++
+			}
+			sentences = append(sentences, string(runes[start:j]))
+			start = j
+			i = j
+			continue
+		}
+		i++
+	}
+	if start < len(runes) {
+		sentences = append(sentences, strings.TrimSpace(string(runes[start:])))
+	}
+	return sentences
+}
+
+// packSentences 贪心地把句子打包进块，加入下一句会超过 sentenceSize 字符时
+// 另起一块；每个新块以上一块末尾 overlap 个字符开头，保留跨块的上下文。
+// 句子不会被拆开，因此过长的句子加上重叠文本后可能使块超过 sentenceSize。
+func packSentences(sentences []string, sentenceSize, overlap int) []string {
+	var chunks []string
+	var current strings.Builder
+	currentLen := 0
+
+	for _, sent := range sentences {
+		sentLen := len([]rune(sent))
+		if currentLen > 0 && currentLen+sentLen > sentenceSize {
+			chunks = append(chunks, current.String())
+			overlapText := tailRunes(current.String(), overlap)
+			current.Reset()
+			current.WriteString(overlapText)
+			currentLen = len([]rune(overlapText))
+		}
+		current.WriteString(sent)
+		currentLen += sentLen
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+// normalizeWhitespace 压缩多余的空白和换行，PDF抽取的文本尤其需要这一步。
+func normalizeWhitespace(text string) string {
+	text = collapseWhitespaceRE.ReplaceAllString(text, " ")
+	text = collapseBlankLinesRE.ReplaceAllString(text, "\n\n")
+	return text
+}
+
+// isMarkdownHeading 判断一行是否是 Markdown 标题（# ~ ######）。
+func isMarkdownHeading(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	i := 0
+	for i < len(trimmed) && i < 6 && trimmed[i] == '#' {
+		i++
+	}
+	return i > 0 && i < len(trimmed) && trimmed[i] == ' '
+}
+
+// tailRunes 返回 s 末尾最多 n 个字符，n<=0 时返回空字符串。
+func tailRunes(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[len(r)-n:])
+}
@@ -0,0 +1,75 @@
+package splitter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tmc/langchaingo/embeddings"
+)
+
+// SemanticSplitter 把文本先按 ChineseSplitter 的句子切分规则拆成句子，
+// 再对每个句子做向量化，当相邻句子的余弦相似度低于 Threshold 时另起一块，
+// 这样切出来的块更贴近话题边界，而不是固定字符数。
+type SemanticSplitter struct {
+	Embedder  embeddings.Embedder
+	Threshold float64
+}
+
+// NewSemanticSplitter 创建一个 SemanticSplitter，threshold<=0 时使用默认值。
+func NewSemanticSplitter(embedder embeddings.Embedder, threshold float64) *SemanticSplitter {
+	if threshold <= 0 {
+		threshold = DefaultSemanticThreshold
+	}
+	return &SemanticSplitter{Embedder: embedder, Threshold: threshold}
+}
+
+// SplitText 实现 textsplitter.TextSplitter。
+func (s *SemanticSplitter) SplitText(text string) ([]string, error) {
+	sentences := splitIntoSentences(text)
+	if len(sentences) == 0 {
+		return nil, nil
+	}
+	if len(sentences) == 1 {
+		return sentences, nil
+	}
+
+	vectors, err := s.Embedder.EmbedDocuments(context.Background(), sentences)
+	if err != nil {
+		return nil, fmt.Errorf("句子向量化失败: %v", err)
+	}
+
+	var chunks []string
+	var current strings.Builder
+	current.WriteString(sentences[0])
+
+	for i := 1; i < len(sentences); i++ {
+		if cosineSimilarity(vectors[i-1], vectors[i]) < s.Threshold {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		current.WriteString(sentences[i])
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks, nil
+}
+
+// cosineSimilarity 计算两个向量的余弦相似度，长度不一致或零向量时返回 0。
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,67 @@
+// Package splitter 提供除 langchaingo 默认的 RecursiveCharacter 之外的
+// 中文场景分块策略：按中文句子边界切分的 ChineseSplitter，以及按语义
+// 相似度断句的 SemanticSplitter。
+package splitter
+
+import (
+	"fmt"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/textsplitter"
+)
+
+const (
+	// ModeRecursive 使用 langchaingo 自带的递归字符分割器
+	ModeRecursive = "recursive"
+	// ModeChinese 按中文句子终止符、段落与标题切分，再按长度贪心打包
+	ModeChinese = "chinese"
+	// ModeSemantic 按相邻句子的向量相似度断句
+	ModeSemantic = "semantic"
+)
+
+// DefaultSentenceSize 是中文分割器默认的单块目标字符数。
+const DefaultSentenceSize = 250
+
+// DefaultSemanticThreshold 是语义分割器默认的相邻句子相似度阈值，
+// 低于该阈值视为话题发生了切换，开始新的一块。
+const DefaultSemanticThreshold = 0.5
+
+// Options 聚合三种分割模式各自用到的参数，未用到的字段按模式忽略。
+type Options struct {
+	// Mode 取值 recursive|chinese|semantic
+	Mode string
+	// ChunkSize、ChunkOverlap 供 recursive 模式使用
+	ChunkSize    int
+	ChunkOverlap int
+	// SentenceSize 是 chinese 模式单块的目标字符数
+	SentenceSize int
+	// Overlap 是 chinese 模式相邻块之间的滑动重叠字符数
+	Overlap int
+	// SemanticThreshold 是 semantic 模式的相似度阈值
+	SemanticThreshold float64
+	// Embedder 是 semantic 模式用来给每个句子生成向量的embedder，必填
+	Embedder embeddings.Embedder
+}
+
+// New 根据 opts.Mode 创建对应的 textsplitter.TextSplitter 实现。
+func New(opts Options) (textsplitter.TextSplitter, error) {
+	switch opts.Mode {
+	case ModeChinese:
+		return NewChineseSplitter(opts.SentenceSize, opts.Overlap), nil
+
+	case ModeSemantic:
+		if opts.Embedder == nil {
+			return nil, fmt.Errorf("semantic分割模式需要提供embedder")
+		}
+		return NewSemanticSplitter(opts.Embedder, opts.SemanticThreshold), nil
+
+	case ModeRecursive, "":
+		return textsplitter.NewRecursiveCharacter(
+			textsplitter.WithChunkSize(opts.ChunkSize),
+			textsplitter.WithChunkOverlap(opts.ChunkOverlap),
+		), nil
+
+	default:
+		return nil, fmt.Errorf("不支持的分割器类型: %s", opts.Mode)
+	}
+}
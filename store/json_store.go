@@ -0,0 +1,180 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// jsonRecord 是 JSONStore 持久化到磁盘的单条记录：文本、向量与元数据。
+type jsonRecord struct {
+	PageContent string         `json:"page_content"`
+	Embedding   []float32      `json:"embedding"`
+	Metadata    map[string]any `json:"metadata"`
+}
+
+// JSONStore 是一个零依赖的本地向量存储实现：embedding 和元数据整体保存在
+// 一个 JSON 文件里，检索时用暴力余弦相似度扫描全部记录。适合在没有
+// Postgres/Chroma/Qdrant 的情况下跑通整个 demo。
+type JSONStore struct {
+	path     string
+	embedder embeddings.Embedder
+
+	mu      sync.Mutex
+	records []jsonRecord
+}
+
+// NewJSONStore 创建/加载一个 JSONStore，path 不存在时视为空集合。
+// path 会按 collectionName 拆分出一个独立文件，避免不同来源共用同一份
+// 持久化文件导致 IsEmpty 误判为"已摄取"而互相跳过。
+func NewJSONStore(path, collectionName string, embedder embeddings.Embedder) (*JSONStore, error) {
+	if path == "" {
+		path = "vectorstore.json"
+	}
+	path = collectionFilePath(path, collectionName)
+	s := &JSONStore{path: path, embedder: embedder}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("读取向量存储文件失败: %v", err)
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &s.records); err != nil {
+			return nil, fmt.Errorf("解析向量存储文件失败: %v", err)
+		}
+	}
+	return s, nil
+}
+
+// AddDocuments 实现 vectorstores.VectorStore，对文档批量做向量化后追加写入。
+func (s *JSONStore) AddDocuments(ctx context.Context, docs []schema.Document, _ ...vectorstores.Option) ([]string, error) {
+	texts := make([]string, len(docs))
+	for i, d := range docs {
+		texts[i] = d.PageContent
+	}
+
+	vectors, err := s.embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("文档向量化失败: %v", err)
+	}
+
+	s.mu.Lock()
+	ids := make([]string, len(docs))
+	for i, d := range docs {
+		s.records = append(s.records, jsonRecord{
+			PageContent: d.PageContent,
+			Embedding:   vectors[i],
+			Metadata:    d.Metadata,
+		})
+		ids[i] = fmt.Sprintf("%d", len(s.records)-1)
+	}
+	err = s.persistLocked()
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// SimilaritySearch 实现 vectorstores.VectorStore，对查询文本做向量化后
+// 在全部记录上做暴力余弦相似度检索，按分数倒序返回前 numDocuments 条。
+func (s *JSONStore) SimilaritySearch(ctx context.Context, query string, numDocuments int, opts ...vectorstores.Option) ([]schema.Document, error) {
+	options := &vectorstores.Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	queryVector, err := s.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("查询向量化失败: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	type scored struct {
+		record jsonRecord
+		score  float32
+	}
+	candidates := make([]scored, 0, len(s.records))
+	for _, r := range s.records {
+		candidates = append(candidates, scored{record: r, score: cosineSimilarity(queryVector, r.Embedding)})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+
+	results := make([]schema.Document, 0, numDocuments)
+	for _, c := range candidates {
+		if options.ScoreThreshold > 0 && c.score < options.ScoreThreshold {
+			continue
+		}
+		results = append(results, schema.Document{
+			PageContent: c.record.PageContent,
+			Metadata:    c.record.Metadata,
+			Score:       1 - c.score,
+		})
+		if len(results) >= numDocuments {
+			break
+		}
+	}
+	return results, nil
+}
+
+// IsEmpty 实现 Store。
+func (s *JSONStore) IsEmpty(ctx context.Context) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records) == 0, nil
+}
+
+// persistLocked 把当前记录写回磁盘，调用方需持有 s.mu。
+func (s *JSONStore) persistLocked() error {
+	raw, err := json.Marshal(s.records)
+	if err != nil {
+		return fmt.Errorf("序列化向量存储失败: %v", err)
+	}
+	if err := os.WriteFile(s.path, raw, 0o644); err != nil {
+		return fmt.Errorf("写入向量存储文件失败: %v", err)
+	}
+	return nil
+}
+
+// collectionFilePath 把 collectionName 插入 path 的扩展名之前，
+// collectionName 为空时原样返回 path。
+func collectionFilePath(path, collectionName string) string {
+	if collectionName == "" {
+		return path
+	}
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	return fmt.Sprintf("%s.%s%s", base, collectionName, ext)
+}
+
+// cosineSimilarity 计算两个向量的余弦相似度，长度不一致或零向量时返回 0。
+func cosineSimilarity(a, b []float32) float32 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB)))
+}
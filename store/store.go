@@ -0,0 +1,119 @@
+// Package store 提供可插拔的向量数据库后端选择（VectorStoreFactory），
+// 屏蔽 pgvector / json / chroma / qdrant 之间的差异，统一通过 New 创建。
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/chroma"
+	"github.com/tmc/langchaingo/vectorstores/pgvector"
+	"github.com/tmc/langchaingo/vectorstores/qdrant"
+)
+
+const (
+	// KindPGVector 使用 PostgreSQL + pgvector 扩展作为后端
+	KindPGVector = "pgvector"
+	// KindJSON 使用本地 JSON 文件作为零依赖后端，适合没有数据库的演示环境
+	KindJSON = "json"
+	// KindChroma 使用 Chroma HTTP 服务作为后端
+	KindChroma = "chroma"
+	// KindQdrant 使用 Qdrant HTTP 服务作为后端
+	KindQdrant = "qdrant"
+)
+
+// Store 在 langchaingo 的 vectorstores.VectorStore 基础上增加了 IsEmpty，
+// 用于判断某个集合是否已经写入过文档，从而在重复摄取同一来源时跳过写入。
+type Store interface {
+	vectorstores.VectorStore
+	IsEmpty(ctx context.Context) (bool, error)
+}
+
+// Options 聚合创建任意后端所需的连接参数，未用到的字段按后端各自忽略。
+type Options struct {
+	// ConnectionURL 用于 pgvector（Postgres DSN）
+	ConnectionURL string
+	// ServerURL 用于 chroma/qdrant 的 HTTP 服务地址
+	ServerURL string
+	// CollectionName 是集合/命名空间名称，建议通过 CollectionNameForSource 生成
+	CollectionName string
+	// JSONPath 是 json 后端的持久化文件路径
+	JSONPath string
+}
+
+// New 根据 kind 创建对应的向量存储后端。
+func New(ctx context.Context, kind string, embedder embeddings.Embedder, opts Options) (Store, error) {
+	switch kind {
+	case KindPGVector:
+		s, err := pgvector.New(
+			ctx,
+			pgvector.WithConnectionURL(opts.ConnectionURL),
+			pgvector.WithEmbedder(embedder),
+			pgvector.WithCollectionName(opts.CollectionName),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("初始化pgvector存储失败: %v", err)
+		}
+		return &checkedStore{VectorStore: &s}, nil
+
+	case KindChroma:
+		s, err := chroma.New(
+			chroma.WithChromaURL(opts.ServerURL),
+			chroma.WithEmbedder(embedder),
+			chroma.WithNameSpace(opts.CollectionName),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("初始化chroma存储失败: %v", err)
+		}
+		return &checkedStore{VectorStore: &s}, nil
+
+	case KindQdrant:
+		serverURL, err := url.Parse(opts.ServerURL)
+		if err != nil {
+			return nil, fmt.Errorf("解析qdrant地址失败: %v", err)
+		}
+		s, err := qdrant.New(
+			qdrant.WithURL(*serverURL),
+			qdrant.WithEmbedder(embedder),
+			qdrant.WithCollectionName(opts.CollectionName),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("初始化qdrant存储失败: %v", err)
+		}
+		return &checkedStore{VectorStore: &s}, nil
+
+	case KindJSON, "":
+		return NewJSONStore(opts.JSONPath, opts.CollectionName, embedder)
+
+	default:
+		return nil, fmt.Errorf("不支持的向量存储类型: %s", kind)
+	}
+}
+
+// checkedStore 把任意 vectorstores.VectorStore 适配成 Store，
+// IsEmpty 通过一次宽泛的相似度搜索来判断集合里是否已经有数据，
+// 这样无需为每个后端单独实现计数查询。
+type checkedStore struct {
+	vectorstores.VectorStore
+}
+
+// IsEmpty 实现 Store。
+func (c *checkedStore) IsEmpty(ctx context.Context) (bool, error) {
+	results, err := c.SimilaritySearch(ctx, " ", 1)
+	if err != nil {
+		return false, err
+	}
+	return len(results) == 0, nil
+}
+
+// CollectionNameForSource 把来源地址哈希成一个稳定的集合名，
+// 这样重复摄取同一个来源时会命中同一个集合，而不是每次生成随机 UUID。
+func CollectionNameForSource(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return "rag_" + hex.EncodeToString(sum[:])[:16]
+}